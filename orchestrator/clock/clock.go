@@ -0,0 +1,39 @@
+// Package clock abstracts time so time-driven orchestrator behavior (the
+// Monitor tick loop) can be driven deterministically in tests and the sim
+// harness instead of through time.Sleep/time.Ticker directly.
+package clock
+
+import "time"
+
+// Ticker is the subset of *time.Ticker callers need: a channel to receive
+// from and a way to stop it.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// Clock abstracts time.Now and time.NewTicker so production code can use
+// the real wall clock while tests and the sim harness drive a Fake one.
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) Ticker
+}
+
+// Real is a Clock backed by the actual wall clock and time.NewTicker.
+type Real struct{}
+
+// NewReal returns the real wall-clock Clock.
+func NewReal() Clock { return Real{} }
+
+func (Real) Now() time.Time { return time.Now() }
+
+func (Real) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+type realTicker struct {
+	t *time.Ticker
+}
+
+func (r realTicker) C() <-chan time.Time { return r.t.C }
+func (r realTicker) Stop()               { r.t.Stop() }
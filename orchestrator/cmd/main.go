@@ -1,56 +1,141 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"log"
-	"time"
+	"os"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/Luk13Mad/StreetGridOS/orchestrator"
+	"github.com/Luk13Mad/StreetGridOS/orchestrator/dispatch"
+	"github.com/Luk13Mad/StreetGridOS/orchestrator/telemetry"
+	"github.com/Luk13Mad/StreetGridOS/orchestrator/transport"
+	"github.com/Luk13Mad/StreetGridOS/sim"
 )
 
-// Node represents a participant or anchor in the microgrid.
-type Node struct {
-	ID             string
-	Type           string // "anchor" or "participant"
-	BatteryKWh     float64
-	CurrentLoadKW  float64
-	IsOnline       bool
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "sim" {
+		if err := runSim(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "streetgrid sim:", err)
+			os.Exit(1)
+		}
+		return
+	}
+	runServe()
 }
 
-// MicrogridOrchestrator manages the state of the street.
-type MicrogridOrchestrator struct {
-	Nodes map[string]*Node
-}
+// runSim implements `streetgrid sim run --scenario FILE --duration DURATION`.
+func runSim(args []string) error {
+	if len(args) == 0 || args[0] != "run" {
+		return fmt.Errorf(`expected "run", e.g. streetgrid sim run --scenario blackout.yaml --duration 24h`)
+	}
 
-func NewOrchestrator() *MicrogridOrchestrator {
-	return &MicrogridOrchestrator{
-		Nodes: make(map[string]*Node),
+	fs := flag.NewFlagSet("sim run", flag.ExitOnError)
+	scenarioPath := fs.String("scenario", "", "path to a scenario YAML file")
+	duration := fs.Duration("duration", 0, "how long to simulate, e.g. 24h (overrides the scenario file's duration if set)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *scenarioPath == "" {
+		return fmt.Errorf("--scenario is required")
 	}
-}
 
-func (m *MicrogridOrchestrator) RegisterNode(id string, nodeType string) {
-	m.Nodes[id] = &Node{
-		ID:       id,
-		Type:     nodeType,
-		IsOnline: true,
+	scenario, err := sim.LoadScenario(*scenarioPath)
+	if err != nil {
+		return err
+	}
+	if *duration != 0 {
+		scenario.Duration = *duration
 	}
-	log.Printf("Registered Node: %s (%s)", id, nodeType)
-}
 
-func (m *MicrogridOrchestrator) Monitor() {
-	// Simple mock loop
-	for {
-		log.Println("Orchestrator heartbeat...")
-		// Logic to query nodes would go here
-		time.Sleep(5 * time.Second)
+	report, err := sim.Run(scenario)
+	if err != nil {
+		return err
 	}
+
+	fmt.Printf("energy served:      %.2f kWh\n", report.EnergyServedKWh)
+	fmt.Printf("unserved energy:    %.2f%%\n", report.UnservedEnergyPercent)
+	fmt.Printf("leader changes:     %d\n", report.LeaderChanges)
+	fmt.Println("average SoC per node:")
+	for id, soc := range report.AverageSoCPerNode {
+		fmt.Printf("  %-20s %.2f kWh\n", id, soc)
+	}
+	return nil
 }
 
-func main() {
+// runServe runs the orchestrator against real (or manually registered)
+// nodes, as it always has.
+func runServe() {
+	metricsAddr := flag.String("metrics-addr", ":9090", "bind address for the /metrics endpoint")
+	tlsCert := flag.String("tls-cert", "", "optional TLS certificate file for the metrics endpoint")
+	tlsKey := flag.String("tls-key", "", "optional TLS key file for the metrics endpoint")
+	grpcPort := flag.Int("grpc-port", 7001, "port this anchor accepts telemetry streams on and advertises over mDNS")
+	flag.Parse()
+
 	fmt.Println("StreetGrid Orchestrator v0.1.0")
 
-	orch := NewOrchestrator()
-	orch.RegisterNode("anchor_01", "anchor")
-	orch.RegisterNode("participant_01", "participant")
+	tp, err := telemetry.NewTracerProvider(os.Stdout)
+	if err != nil {
+		panic(err)
+	}
+	defer tp.Shutdown(context.Background())
+	otel.SetTracerProvider(tp)
+
+	metrics := telemetry.NewMetrics()
+	metricsServer := telemetry.NewMetricsServer(*metricsAddr, metrics)
+	go func() {
+		if err := metricsServer.ListenAndServe(*tlsCert, *tlsKey); err != nil {
+			log.Printf("metrics server stopped: %v", err)
+		}
+	}()
+
+	decisionLog, err := dispatch.OpenDecisionLog("dispatch.log")
+	if err != nil {
+		panic(err)
+	}
+	defer decisionLog.Close()
+
+	engine := dispatch.NewEngine("anchor_01", dispatch.ProportionalFairStrategy{SurplusThresholdKWh: 1.0}, decisionLog, nil)
+
+	grpcTransport := transport.NewGRPCTransport()
+	go func() {
+		if err := grpcTransport.Serve(fmt.Sprintf(":%d", *grpcPort)); err != nil {
+			log.Printf("grpc transport stopped: %v", err)
+		}
+	}()
+	defer grpcTransport.Close()
+
+	orch := orchestrator.NewOrchestratorWithTransport(grpcTransport)
+	orch.SetDispatchEngine(engine)
+	orch.SetMetrics(metrics)
+
+	ctx := context.Background()
+	orch.RegisterNode(ctx, "anchor_01", "anchor")
+	orch.RegisterNode(ctx, "participant_01", "participant")
+
+	shutdownAdvertise, err := transport.Advertise("anchor_01", *grpcPort)
+	if err != nil {
+		log.Printf("mdns: advertise anchor_01: %v", err)
+	} else {
+		defer shutdownAdvertise()
+	}
+
+	discovered, err := transport.Discover(ctx)
+	if err != nil {
+		log.Printf("mdns: discover: %v", err)
+	} else {
+		go func() {
+			for d := range discovered {
+				orch.RegisterDiscovered(ctx, d, "anchor")
+			}
+		}()
+	}
 
 	// Start monitoring (blocking for now)
-	orch.Monitor()
+	if err := orch.Monitor(ctx); err != nil {
+		fmt.Println("orchestrator stopped:", err)
+	}
 }
@@ -0,0 +1,128 @@
+package dispatch
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// electionTimeoutMin/Max bound the randomized election timeout each anchor
+// waits before starting a new vote, per the usual Raft jitter trick: if
+// every anchor timed out at the same instant they'd split votes forever.
+const (
+	electionTimeoutMin = 150 * time.Millisecond
+	electionTimeoutMax = 300 * time.Millisecond
+)
+
+// VoteRequest is broadcast by a candidate anchor asking peers to elect it
+// dispatch leader for Term.
+type VoteRequest struct {
+	Term         uint64
+	CandidateID  string
+	LastLogIndex uint64
+}
+
+// VoteResponse is a peer anchor's answer to a VoteRequest.
+type VoteResponse struct {
+	Term        uint64
+	VoteGranted bool
+}
+
+// Peer is the subset of anchor-to-anchor RPCs the election and replication
+// protocol needs. A concrete NodeTransport (see the transport package)
+// supplies the network implementation; tests can supply an in-memory one.
+type Peer interface {
+	ID() string
+	RequestVote(ctx context.Context, req VoteRequest) (VoteResponse, error)
+	Replicate(ctx context.Context, entry LogEntry) error
+}
+
+func randomElectionTimeout() time.Duration {
+	span := electionTimeoutMax - electionTimeoutMin
+	return electionTimeoutMin + time.Duration(rand.Int63n(int64(span)))
+}
+
+// runElection broadcasts a VoteRequest for the next term and reports whether
+// this anchor won a majority, along with the term it ran the vote under.
+func (e *Engine) runElection(ctx context.Context) (won bool, term uint64) {
+	e.mu.Lock()
+	e.term++
+	term = e.term
+	e.votedFor = e.nodeID
+	e.mu.Unlock()
+
+	if e.OnElection != nil {
+		e.OnElection(term)
+	}
+
+	votes := 1 // vote for self
+	req := VoteRequest{
+		Term:         term,
+		CandidateID:  e.nodeID,
+		LastLogIndex: e.log.LastIndex(),
+	}
+	for _, peer := range e.peers {
+		resp, err := peer.RequestVote(ctx, req)
+		if err != nil {
+			continue
+		}
+		if resp.Term > term {
+			// A higher term exists; step down regardless of outcome.
+			e.mu.Lock()
+			e.term = resp.Term
+			e.leader = false
+			e.mu.Unlock()
+			return false, resp.Term
+		}
+		if resp.VoteGranted {
+			votes++
+		}
+	}
+
+	// Same majority formula as replicate: a majority of all len(e.peers)+1
+	// anchors, so election and replication never disagree on what quorum is.
+	majority := (len(e.peers)+1)/2 + 1
+	won = votes >= majority
+	e.mu.Lock()
+	e.leader = won
+	e.mu.Unlock()
+	return won, term
+}
+
+// HandleVoteRequest answers a VoteRequest from a peer candidate, granting at
+// most one vote per term on a first-come basis.
+func (e *Engine) HandleVoteRequest(req VoteRequest) VoteResponse {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if req.Term < e.term {
+		return VoteResponse{Term: e.term, VoteGranted: false}
+	}
+	if req.Term > e.term {
+		e.term = req.Term
+		e.votedFor = ""
+		e.leader = false
+	}
+	if (e.votedFor == "" || e.votedFor == req.CandidateID) && req.LastLogIndex >= e.log.LastIndex() {
+		e.votedFor = req.CandidateID
+		return VoteResponse{Term: e.term, VoteGranted: true}
+	}
+	return VoteResponse{Term: e.term, VoteGranted: false}
+}
+
+// IsLeader reports whether this engine currently believes itself to be the
+// dispatch leader.
+func (e *Engine) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.leader
+}
+
+// Demote forces this engine to give up leadership without a real failure,
+// so the next Tick triggers a fresh election. It exists for tests and the
+// sim harness to exercise leader handover deterministically.
+func (e *Engine) Demote() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.leader = false
+}
@@ -0,0 +1,139 @@
+package dispatch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// DefaultLinkCapKW caps how much power a single dispatch decision may move
+// across one source/sink link per tick, so a large surplus can't be dumped
+// onto a single node in one step.
+const DefaultLinkCapKW = 10.0
+
+// Engine coordinates dispatch decisions across the anchors of a microgrid.
+// Every anchor runs an identical Engine state machine; exactly one of them
+// is elected leader at a time and computes decisions, which are replicated
+// to followers before they are considered committed.
+type Engine struct {
+	mu sync.Mutex
+
+	nodeID   string
+	term     uint64
+	votedFor string
+	leader   bool
+
+	peers     []Peer
+	strategy  Strategy
+	linkCapKW float64
+	log       *DecisionLog
+
+	// OnElection, if set, is called with the new term every time this
+	// engine starts an election, letting callers track election churn
+	// (e.g. as a Prometheus counter) without the dispatch package needing
+	// to know about telemetry.
+	OnElection func(term uint64)
+}
+
+// NewEngine builds a dispatch engine for the anchor identified by nodeID,
+// persisting committed decisions to log and replicating them to peers.
+func NewEngine(nodeID string, strategy Strategy, log *DecisionLog, peers []Peer) *Engine {
+	return &Engine{
+		nodeID:    nodeID,
+		strategy:  strategy,
+		linkCapKW: DefaultLinkCapKW,
+		log:       log,
+		peers:     peers,
+	}
+}
+
+// SetPeers replaces the set of anchors this engine elects against and
+// replicates to. It exists alongside the NewEngine peers argument for
+// callers (like the sim harness) that must construct every anchor's engine
+// before they can hand each one the others as peers.
+func (e *Engine) SetPeers(peers []Peer) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.peers = peers
+}
+
+// Tick runs one control-loop step: if this anchor isn't the leader it first
+// tries to become one, then (if leading) computes dispatch decisions from
+// nodes, replicates them to a majority of peers, commits them to the local
+// log, and returns them for the caller to actuate.
+func (e *Engine) Tick(ctx context.Context, nodes []NodeView) ([]DispatchDecision, error) {
+	if !e.IsLeader() {
+		won, _ := e.runElection(ctx)
+		if !won {
+			return nil, nil
+		}
+	}
+
+	var surplus, deficit []NodeView
+	for _, n := range nodes {
+		switch {
+		case n.BatteryKWh-n.CurrentLoadKW > 0:
+			surplus = append(surplus, n)
+		case n.BatteryKWh-n.CurrentLoadKW < 0:
+			deficit = append(deficit, n)
+		}
+	}
+
+	decisions := e.strategy.Plan(surplus, deficit, e.linkCapKW)
+	if len(decisions) == 0 {
+		return nil, nil
+	}
+
+	committed := make([]DispatchDecision, 0, len(decisions))
+	for _, d := range decisions {
+		e.mu.Lock()
+		term := e.term
+		index := e.log.LastIndex() + 1
+		e.mu.Unlock()
+
+		entry := LogEntry{Term: term, Index: index, Decision: d}
+		if err := e.replicate(ctx, entry); err != nil {
+			return committed, fmt.Errorf("dispatch: replicate decision: %w", err)
+		}
+		if err := e.log.Append(entry); err != nil {
+			return committed, fmt.Errorf("dispatch: commit decision: %w", err)
+		}
+		committed = append(committed, d)
+	}
+	return committed, nil
+}
+
+// replicate sends entry to every peer and waits for acknowledgement from a
+// majority (including this leader) before the caller is allowed to commit
+// it locally. Peer failures are tolerated as long as a majority still acks.
+func (e *Engine) replicate(ctx context.Context, entry LogEntry) error {
+	if len(e.peers) == 0 {
+		return nil // single-anchor deployment: no one to replicate to
+	}
+	acks := 1 // the leader itself counts
+	for _, peer := range e.peers {
+		if err := peer.Replicate(ctx, entry); err == nil {
+			acks++
+		}
+	}
+	majority := (len(e.peers)+1)/2 + 1 // majority of all len(e.peers)+1 anchors, leader included
+	if acks < majority {
+		return fmt.Errorf("only %d/%d anchors acked entry %d", acks, len(e.peers)+1, entry.Index)
+	}
+	return nil
+}
+
+// Apply applies a replicated entry on a follower, keyed on (term, index) so
+// re-delivery of the same entry is a no-op.
+func (e *Engine) Apply(entry LogEntry) error {
+	if e.log.Has(entry.Term, entry.Index) {
+		return nil
+	}
+	return e.log.Append(entry)
+}
+
+// Bootstrap loads committed entries from the decision log at path, for an
+// anchor recovering from a crash or restart.
+func Bootstrap(path string) ([]LogEntry, error) {
+	return ReplayLog(path)
+}
@@ -0,0 +1,155 @@
+package dispatch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+)
+
+// loopbackPeer routes RequestVote/Replicate straight to another engine's
+// handlers, mirroring sim's inProcessPeer so tests exercise the real
+// election/replication protocol without a network.
+type loopbackPeer struct {
+	id     string
+	engine *Engine
+}
+
+func (p *loopbackPeer) ID() string { return p.id }
+
+func (p *loopbackPeer) RequestVote(_ context.Context, req VoteRequest) (VoteResponse, error) {
+	return p.engine.HandleVoteRequest(req), nil
+}
+
+func (p *loopbackPeer) Replicate(_ context.Context, entry LogEntry) error {
+	return p.engine.Apply(entry)
+}
+
+// downPeer simulates an anchor that cannot be reached: every RPC fails, the
+// way a real Peer would if the network call timed out.
+type downPeer struct{ id string }
+
+func (p *downPeer) ID() string { return p.id }
+
+func (p *downPeer) RequestVote(context.Context, VoteRequest) (VoteResponse, error) {
+	return VoteResponse{}, errors.New("downPeer: unreachable")
+}
+
+func (p *downPeer) Replicate(context.Context, LogEntry) error {
+	return errors.New("downPeer: unreachable")
+}
+
+func newTestEngine(t *testing.T, nodeID string) *Engine {
+	t.Helper()
+	f, err := os.CreateTemp("", "streetgrid-dispatch-test-"+nodeID+"-*.log")
+	if err != nil {
+		t.Fatalf("create temp log: %v", err)
+	}
+	path := f.Name()
+	f.Close()
+	t.Cleanup(func() { os.Remove(path) })
+
+	log, err := OpenDecisionLog(path)
+	if err != nil {
+		t.Fatalf("open decision log: %v", err)
+	}
+	t.Cleanup(func() { log.Close() })
+
+	return NewEngine(nodeID, ProportionalFairStrategy{SurplusThresholdKWh: 0.5}, log, nil)
+}
+
+// TestTickElectsLeaderWithOneDownPeer exercises a 3-anchor deployment
+// (2 peers) where one peer is unreachable. A correct majority of 2 out of 3
+// must still be reachable by the candidate's own vote plus the live peer, so
+// the candidate should win the election and commit a decision despite the
+// down peer.
+func TestTickElectsLeaderWithOneDownPeer(t *testing.T) {
+	candidate := newTestEngine(t, "anchor_00")
+	live := newTestEngine(t, "anchor_01")
+
+	candidate.SetPeers([]Peer{
+		&loopbackPeer{id: live.nodeID, engine: live},
+		&downPeer{id: "anchor_02"},
+	})
+
+	nodes := []NodeView{
+		{ID: "anchor_00", BatteryKWh: 10, CurrentLoadKW: 0},
+		{ID: "participant_00", BatteryKWh: 0, CurrentLoadKW: 5},
+	}
+
+	decisions, err := candidate.Tick(context.Background(), nodes)
+	if err != nil {
+		t.Fatalf("Tick with one down peer: %v", err)
+	}
+	if !candidate.IsLeader() {
+		t.Fatal("candidate should have won the election with 2/3 anchors reachable")
+	}
+	if len(decisions) == 0 {
+		t.Fatal("expected a dispatch decision to be committed")
+	}
+	if got, want := live.log.LastIndex(), uint64(1); got != want {
+		t.Fatalf("live peer should have applied the replicated entry: last index = %d, want %d", got, want)
+	}
+}
+
+// TestTickWithoutMajorityNeverCommits confirms that when a majority of
+// anchors cannot be reached, the candidate neither wins the election nor
+// commits a decision, instead of going ahead on a minority. This is the case
+// the original len(e.peers)/2+2 / len(e.peers)/2+1 formulas got wrong: they
+// either demanded unanimity or were inconsistent between election and
+// replication.
+func TestTickWithoutMajorityNeverCommits(t *testing.T) {
+	candidate := newTestEngine(t, "anchor_00")
+	candidate.SetPeers([]Peer{
+		&downPeer{id: "anchor_01"},
+		&downPeer{id: "anchor_02"},
+	})
+
+	nodes := []NodeView{
+		{ID: "anchor_00", BatteryKWh: 10, CurrentLoadKW: 0},
+		{ID: "participant_00", BatteryKWh: 0, CurrentLoadKW: 5},
+	}
+
+	decisions, err := candidate.Tick(context.Background(), nodes)
+	if err != nil {
+		t.Fatalf("Tick: %v", err)
+	}
+	if len(decisions) != 0 {
+		t.Fatal("expected no decisions to be committed with only 1/3 anchors reachable")
+	}
+	if candidate.IsLeader() {
+		t.Fatal("candidate should not have won the election with only 1/3 anchors reachable")
+	}
+}
+
+// TestRunElectionBoundary pins down the exact vote count that flips a
+// 5-anchor election (4 peers) from lost to won: a majority of 5 anchors is
+// 3, so 2 grants plus the candidate's own vote must lose, and 3 must win.
+// This is the boundary the election.go len(e.peers)/2+1 formula got wrong
+// (it would have called 1 grant a win already) and that replicate's old
+// len(e.peers)/2+2 formula disagreed with.
+func TestRunElectionBoundary(t *testing.T) {
+	newCandidateWithGrantingPeers := func(t *testing.T, grantingPeers int) *Engine {
+		t.Helper()
+		e := newTestEngine(t, "candidate")
+		peers := make([]Peer, 0, 4)
+		for i := 0; i < grantingPeers; i++ {
+			peers = append(peers, &loopbackPeer{id: "granter", engine: newTestEngine(t, "granter")})
+		}
+		for i := grantingPeers; i < 4; i++ {
+			peers = append(peers, &downPeer{id: "downPeer"})
+		}
+		e.SetPeers(peers)
+		return e
+	}
+
+	won, _ := newCandidateWithGrantingPeers(t, 1).runElection(context.Background())
+	if won {
+		t.Fatal("1 granting peer out of 4 (2 votes total) should not reach a majority of 3")
+	}
+
+	won, _ = newCandidateWithGrantingPeers(t, 2).runElection(context.Background())
+	if !won {
+		t.Fatal("2 granting peers out of 4 (3 votes total) should reach a majority of 3")
+	}
+}
@@ -0,0 +1,144 @@
+package dispatch
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// DispatchDecision is a single instruction to move power from one node to
+// another. Decisions are appended to the DecisionLog before they are acted
+// on, so a crashed anchor can recover the in-flight schedule on restart.
+type DispatchDecision struct {
+	Source   string        `json:"source"`
+	Sink     string        `json:"sink"`
+	KW       float64       `json:"kw"`
+	Duration time.Duration `json:"duration"`
+	Deadline time.Time     `json:"deadline"`
+}
+
+// LogEntry wraps a DispatchDecision with the Raft term/index pair it was
+// committed under, so followers can apply it idempotently.
+type LogEntry struct {
+	Term     uint64           `json:"term"`
+	Index    uint64           `json:"index"`
+	Decision DispatchDecision `json:"decision"`
+}
+
+// DecisionLog is an append-only, newline-delimited JSON log of committed
+// dispatch decisions. It is the persistence the leader writes to before a
+// decision is replicated, and the thing ReplayLog reads on cold start.
+type DecisionLog struct {
+	mu      sync.Mutex
+	file    *os.File
+	entries []LogEntry
+}
+
+// OpenDecisionLog opens (creating if necessary) the log file at path and
+// replays any existing entries into memory.
+func OpenDecisionLog(path string) (*DecisionLog, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("dispatch: open decision log: %w", err)
+	}
+	l := &DecisionLog{file: f}
+	if err := l.replay(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *DecisionLog) replay() error {
+	if _, err := l.file.Seek(0, 0); err != nil {
+		return fmt.Errorf("dispatch: seek decision log: %w", err)
+	}
+	scanner := bufio.NewScanner(l.file)
+	for scanner.Scan() {
+		var entry LogEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return fmt.Errorf("dispatch: corrupt decision log entry: %w", err)
+		}
+		l.entries = append(l.entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("dispatch: read decision log: %w", err)
+	}
+	if _, err := l.file.Seek(0, 2); err != nil {
+		return fmt.Errorf("dispatch: seek decision log: %w", err)
+	}
+	return scanner.Err()
+}
+
+// Append persists entry and keeps it in the in-memory tail used to answer
+// LastIndex/LastTerm during leader election.
+func (l *DecisionLog) Append(entry LogEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("dispatch: marshal log entry: %w", err)
+	}
+	b = append(b, '\n')
+	if _, err := l.file.Write(b); err != nil {
+		return fmt.Errorf("dispatch: append decision log: %w", err)
+	}
+	if err := l.file.Sync(); err != nil {
+		return fmt.Errorf("dispatch: sync decision log: %w", err)
+	}
+	l.entries = append(l.entries, entry)
+	return nil
+}
+
+// Has reports whether an entry for (term, index) has already been applied,
+// so followers can apply replicated entries idempotently.
+func (l *DecisionLog) Has(term, index uint64) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, e := range l.entries {
+		if e.Term == term && e.Index == index {
+			return true
+		}
+	}
+	return false
+}
+
+// LastIndex returns the index of the last committed entry, or 0 if the log
+// is empty.
+func (l *DecisionLog) LastIndex() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.entries) == 0 {
+		return 0
+	}
+	return l.entries[len(l.entries)-1].Index
+}
+
+// Entries returns a copy of every entry committed so far, in order.
+func (l *DecisionLog) Entries() []LogEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := make([]LogEntry, len(l.entries))
+	copy(out, l.entries)
+	return out
+}
+
+// Close closes the underlying log file.
+func (l *DecisionLog) Close() error {
+	return l.file.Close()
+}
+
+// ReplayLog opens the decision log at path and returns every entry
+// previously committed, for an anchor bootstrapping cold from disk.
+func ReplayLog(path string) ([]LogEntry, error) {
+	l, err := OpenDecisionLog(path)
+	if err != nil {
+		return nil, err
+	}
+	defer l.Close()
+	return l.Entries(), nil
+}
@@ -0,0 +1,201 @@
+package dispatch
+
+import "sort"
+
+// NodeView is the subset of node state the dispatch engine needs to make a
+// decision. The orchestrator builds these fresh on every Tick so the engine
+// never holds a reference to live orchestrator state.
+type NodeView struct {
+	ID            string
+	BatteryKWh    float64
+	CurrentLoadKW float64
+}
+
+// Strategy turns a snapshot of node views into a set of dispatch decisions
+// for the current tick. Implementations must be pure functions of their
+// inputs so decisions are reproducible during log replay.
+type Strategy interface {
+	Name() string
+	Plan(surplus, deficit []NodeView, linkCapKW float64) []DispatchDecision
+}
+
+// ProportionalFairStrategy splits available surplus across deficit nodes in
+// proportion to how deep each node's deficit is.
+type ProportionalFairStrategy struct {
+	// SurplusThresholdKWh is how far above CurrentLoadKW a node's battery
+	// must sit before it is considered a surplus source.
+	SurplusThresholdKWh float64
+}
+
+func (s ProportionalFairStrategy) Name() string { return "proportional-fair" }
+
+func (s ProportionalFairStrategy) Plan(surplus, deficit []NodeView, linkCapKW float64) []DispatchDecision {
+	eligible := make([]NodeView, 0, len(surplus))
+	for _, n := range surplus {
+		if headroom(n) > s.SurplusThresholdKWh {
+			eligible = append(eligible, n)
+		}
+	}
+	if len(eligible) == 0 || len(deficit) == 0 {
+		return nil
+	}
+	sort.SliceStable(eligible, func(i, j int) bool {
+		return headroom(eligible[i]) > headroom(eligible[j])
+	})
+
+	ordered := make([]NodeView, len(deficit))
+	copy(ordered, deficit)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return headroom(ordered[i]) < headroom(ordered[j]) // deepest deficit first
+	})
+
+	need := make([]float64, len(ordered))
+	remaining := make([]float64, len(ordered))
+	totalDeficitKW := 0.0
+	for i, n := range ordered {
+		need[i] = -headroom(n)
+		remaining[i] = need[i]
+		totalDeficitKW += need[i]
+	}
+	if totalDeficitKW <= 0 {
+		return nil
+	}
+
+	var decisions []DispatchDecision
+	for _, src := range eligible {
+		available := headroom(src)
+		for i := range ordered {
+			if available <= 0 || remaining[i] <= 0 {
+				continue
+			}
+			// Each sink's share of this source is fixed by how deep its
+			// deficit was relative to the whole deficit pool, so a node
+			// twice as deep in deficit gets roughly twice the power.
+			share := need[i] / totalDeficitKW * headroom(src)
+			if share > linkCapKW {
+				share = linkCapKW
+			}
+			kw := min3(available, remaining[i], share)
+			if kw <= 0 {
+				continue
+			}
+			decisions = append(decisions, DispatchDecision{
+				Source: src.ID,
+				Sink:   ordered[i].ID,
+				KW:     kw,
+			})
+			available -= kw
+			remaining[i] -= kw
+		}
+	}
+	return decisions
+}
+
+// PriorityWeightedStrategy dispatches to deficit nodes in a fixed priority
+// order (e.g. hospitals before street lighting) before falling back to the
+// greedy match for whatever surplus remains.
+type PriorityWeightedStrategy struct {
+	Priority map[string]int // lower value = higher priority
+}
+
+func (s PriorityWeightedStrategy) Name() string { return "priority-weighted" }
+
+func (s PriorityWeightedStrategy) Plan(surplus, deficit []NodeView, linkCapKW float64) []DispatchDecision {
+	ordered := make([]NodeView, len(deficit))
+	copy(ordered, deficit)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return s.Priority[ordered[i].ID] < s.Priority[ordered[j].ID]
+	})
+	return greedyMatch(surplus, ordered, linkCapKW)
+}
+
+// BatterySoCBalancedStrategy prefers drawing from the source with the
+// highest battery state of charge first, to keep the grid's batteries level.
+type BatterySoCBalancedStrategy struct {
+	// CapacityKWh is each node's full battery capacity, used to compute SoC.
+	CapacityKWh map[string]float64
+}
+
+func (s BatterySoCBalancedStrategy) Name() string { return "battery-soc-balanced" }
+
+func (s BatterySoCBalancedStrategy) Plan(surplus, deficit []NodeView, linkCapKW float64) []DispatchDecision {
+	ordered := make([]NodeView, len(surplus))
+	copy(ordered, surplus)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return s.soc(ordered[i]) > s.soc(ordered[j])
+	})
+	return greedyMatch(ordered, deficit, linkCapKW)
+}
+
+func (s BatterySoCBalancedStrategy) soc(n NodeView) float64 {
+	cap := s.CapacityKWh[n.ID]
+	if cap <= 0 {
+		return 0
+	}
+	return n.BatteryKWh / cap
+}
+
+// greedyMatch sorts surplus nodes descending by available headroom and
+// deficit nodes ascending by how negative their headroom is, then pairs them
+// off greedily, capping each link at linkCapKW. It is the heuristic shared by
+// all strategies above; strategies differ only in the ordering they hand in.
+func greedyMatch(surplus, deficit []NodeView, linkCapKW float64) []DispatchDecision {
+	src := make([]NodeView, len(surplus))
+	copy(src, surplus)
+	sort.SliceStable(src, func(i, j int) bool {
+		return headroom(src[i]) > headroom(src[j])
+	})
+
+	snk := make([]NodeView, len(deficit))
+	copy(snk, deficit)
+	sort.SliceStable(snk, func(i, j int) bool {
+		return headroom(snk[i]) < headroom(snk[j])
+	})
+
+	var decisions []DispatchDecision
+	i, j := 0, 0
+	for i < len(src) && j < len(snk) {
+		available := headroom(src[i])
+		needed := -headroom(snk[j])
+		if available <= 0 {
+			i++
+			continue
+		}
+		if needed <= 0 {
+			j++
+			continue
+		}
+		kw := min3(available, needed, linkCapKW)
+		if kw > 0 {
+			decisions = append(decisions, DispatchDecision{
+				Source: src[i].ID,
+				Sink:   snk[j].ID,
+				KW:     kw,
+			})
+			src[i].BatteryKWh -= kw
+			snk[j].CurrentLoadKW -= kw
+		}
+		if headroom(src[i]) <= 0 {
+			i++
+		}
+		if -headroom(snk[j]) <= 0 {
+			j++
+		}
+	}
+	return decisions
+}
+
+func headroom(n NodeView) float64 {
+	return n.BatteryKWh - n.CurrentLoadKW
+}
+
+func min3(a, b, c float64) float64 {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	return m
+}
@@ -0,0 +1,51 @@
+package dispatch
+
+import "testing"
+
+// TestProportionalFairSplitsByDeficitDepth confirms a deficit node twice as
+// deep as another gets roughly twice the power from a shared surplus source,
+// instead of one sink being greedily drained first.
+func TestProportionalFairSplitsByDeficitDepth(t *testing.T) {
+	s := ProportionalFairStrategy{SurplusThresholdKWh: 0}
+	surplus := []NodeView{{ID: "anchor_00", BatteryKWh: 30, CurrentLoadKW: 0}}
+	deficit := []NodeView{
+		{ID: "shallow", BatteryKWh: 0, CurrentLoadKW: 3},
+		{ID: "deep", BatteryKWh: 0, CurrentLoadKW: 6},
+	}
+
+	decisions := s.Plan(surplus, deficit, 100)
+
+	got := make(map[string]float64, len(decisions))
+	for _, d := range decisions {
+		got[d.Sink] = d.KW
+	}
+	if got["shallow"] == 0 || got["deep"] == 0 {
+		t.Fatalf("expected both sinks served proportionally, got %v", got)
+	}
+	if ratio := got["deep"] / got["shallow"]; ratio < 1.9 || ratio > 2.1 {
+		t.Fatalf("deep deficit (2x shallow) should receive ~2x the power, got ratio %.2f (%v)", ratio, got)
+	}
+}
+
+// TestProportionalFairHonorsSurplusThreshold confirms a surplus node whose
+// headroom doesn't clear SurplusThresholdKWh is excluded as a source,
+// instead of the threshold field being silently ignored.
+func TestProportionalFairHonorsSurplusThreshold(t *testing.T) {
+	s := ProportionalFairStrategy{SurplusThresholdKWh: 5}
+	surplus := []NodeView{
+		{ID: "below_threshold", BatteryKWh: 3, CurrentLoadKW: 0},  // headroom 3, below threshold
+		{ID: "above_threshold", BatteryKWh: 10, CurrentLoadKW: 0}, // headroom 10
+	}
+	deficit := []NodeView{{ID: "participant_00", BatteryKWh: 0, CurrentLoadKW: 4}}
+
+	decisions := s.Plan(surplus, deficit, 100)
+
+	for _, d := range decisions {
+		if d.Source == "below_threshold" {
+			t.Fatalf("surplus node below SurplusThresholdKWh should not be used as a source: %+v", d)
+		}
+	}
+	if len(decisions) == 0 {
+		t.Fatal("expected the above-threshold source to serve the deficit node")
+	}
+}
@@ -0,0 +1,406 @@
+// Package orchestrator manages the state of a StreetGridOS microgrid: the
+// anchors and participants on the street, and the control loop that keeps
+// them dispatched.
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/Luk13Mad/StreetGridOS/orchestrator/clock"
+	"github.com/Luk13Mad/StreetGridOS/orchestrator/dispatch"
+	"github.com/Luk13Mad/StreetGridOS/orchestrator/store"
+	"github.com/Luk13Mad/StreetGridOS/orchestrator/telemetry"
+	"github.com/Luk13Mad/StreetGridOS/orchestrator/transport"
+)
+
+// Node represents a participant or anchor in the microgrid.
+type Node struct {
+	ID            string
+	Type          string // "anchor" or "participant"
+	BatteryKWh    float64
+	CurrentLoadKW float64
+	IsOnline      bool
+
+	// Endpoint is the address this node was last known reachable at,
+	// learned either via mDNS discovery or a manual RegisterNode call.
+	Endpoint string
+	// LastSeen is updated whenever a telemetry sample arrives for this node.
+	LastSeen time.Time
+
+	// Telemetry streams live updates from the node when a transport is in
+	// use; Monitor selects over it instead of sleeping. It is nil for nodes
+	// registered without a transport.
+	Telemetry <-chan transport.Telemetry
+}
+
+// MicrogridOrchestrator manages the state of the street.
+type MicrogridOrchestrator struct {
+	// nodesMu guards Nodes. Registration can run concurrently with Monitor's
+	// control loop once discovery is wired in (a node is discovered and
+	// registered from its own goroutine while Monitor ranges over Nodes on
+	// every iteration), so every access to the map must take this lock.
+	nodesMu sync.Mutex
+	Nodes   map[string]*Node
+
+	// Transport delivers telemetry pushes from nodes. It is nil until
+	// NewOrchestratorWithTransport is used, in which case RegisterNode also
+	// wires up the node's Telemetry channel.
+	Transport transport.NodeTransport
+
+	// Dispatch coordinates power flow between anchors and participants on
+	// each tick. It is nil until SetDispatchEngine is called, in which case
+	// Monitor falls back to a plain heartbeat.
+	Dispatch *dispatch.Engine
+
+	// Store persists node Records so a fresh anchor can Restore grid state
+	// after a restart instead of waiting to rediscover every node. It is
+	// nil until SetStore is called, in which case RegisterNode and
+	// applyTelemetry are in-memory only.
+	Store store.StateStore
+
+	// Leases tracks a TTL per participant registration and evicts nodes
+	// that stop heartbeating. It is nil until SetLeaseManager is called.
+	Leases *store.LeaseManager
+	// LeaseGrace is how long a participant may go without a telemetry
+	// sample before Leases evicts it.
+	LeaseGrace time.Duration
+
+	// Metrics, if set via SetMetrics, receives node gauges and dispatch
+	// counters as the orchestrator runs.
+	Metrics *telemetry.Metrics
+
+	// Clock drives Monitor's tick loop. It defaults to the real wall clock;
+	// tests and the sim harness substitute a clock.Fake to step time
+	// deterministically instead of sleeping.
+	Clock clock.Clock
+
+	// TickInterval controls how often Monitor invokes Dispatch.Tick.
+	TickInterval time.Duration
+}
+
+// NewOrchestrator builds an orchestrator with no nodes registered yet and no
+// transport wired in; RegisterNode must be called manually for every node.
+func NewOrchestrator() *MicrogridOrchestrator {
+	return &MicrogridOrchestrator{
+		Nodes:        make(map[string]*Node),
+		Clock:        clock.NewReal(),
+		TickInterval: 5 * time.Second,
+		LeaseGrace:   30 * time.Second,
+	}
+}
+
+// SetClock swaps the clock Monitor's tick loop runs against, e.g. a
+// clock.Fake for tests or the sim harness.
+func (m *MicrogridOrchestrator) SetClock(c clock.Clock) {
+	m.Clock = c
+}
+
+// NewOrchestratorWithTransport builds an orchestrator backed by t. Every
+// node registered afterwards has its Telemetry channel wired to t, and
+// Monitor selects over those channels instead of sleeping.
+func NewOrchestratorWithTransport(t transport.NodeTransport) *MicrogridOrchestrator {
+	m := NewOrchestrator()
+	m.Transport = t
+	return m
+}
+
+// SetDispatchEngine wires a dispatch engine into the orchestrator so Monitor
+// drives it on TickInterval instead of just logging a heartbeat.
+func (m *MicrogridOrchestrator) SetDispatchEngine(engine *dispatch.Engine) {
+	m.Dispatch = engine
+	m.wireOnElection()
+}
+
+// SetStore wires a persistence backend into the orchestrator. Nodes
+// registered afterwards are also written through to it.
+func (m *MicrogridOrchestrator) SetStore(s store.StateStore) {
+	m.Store = s
+}
+
+// SetLeaseManager wires TTL-based eviction into the orchestrator: every
+// participant registered afterwards gets a lease for LeaseGrace, renewed on
+// each telemetry sample, and Monitor removes the node when it expires.
+func (m *MicrogridOrchestrator) SetLeaseManager(lm *store.LeaseManager) {
+	m.Leases = lm
+}
+
+// SetMetrics wires Prometheus reporting into the orchestrator. See the
+// telemetry package for the MetricsServer that exposes these over HTTP.
+func (m *MicrogridOrchestrator) SetMetrics(metrics *telemetry.Metrics) {
+	m.Metrics = metrics
+	m.wireOnElection()
+}
+
+// wireOnElection hooks Dispatch.OnElection up to Metrics.ElectionTermsTotal
+// once both are set. It is called from both SetMetrics and
+// SetDispatchEngine so the counter is wired regardless of which one the
+// caller happens to call first.
+func (m *MicrogridOrchestrator) wireOnElection() {
+	if m.Dispatch != nil && m.Metrics != nil {
+		m.Dispatch.OnElection = func(uint64) { m.Metrics.ElectionTermsTotal.Inc() }
+	}
+}
+
+// RegisterNode registers id as a node of the given type ("anchor" or
+// "participant"), wiring up its transport channel, persisting it to the
+// store, and arming its lease, as configured.
+func (m *MicrogridOrchestrator) RegisterNode(ctx context.Context, id string, nodeType string) {
+	ctx, span := telemetry.StartSpan(ctx, "RegisterNode")
+	defer span.End()
+
+	node := &Node{
+		ID:       id,
+		Type:     nodeType,
+		IsOnline: true,
+	}
+	if m.Transport != nil {
+		node.Telemetry = m.Transport.Telemetry(id)
+	}
+	m.nodesMu.Lock()
+	m.Nodes[id] = node
+	m.nodesMu.Unlock()
+	if m.Store != nil {
+		if err := m.Store.Put(ctx, recordFor(node)); err != nil {
+			log.Printf("store: persist node %s: %v", id, err)
+		}
+	}
+	if m.Leases != nil && nodeType == "participant" {
+		m.Leases.Register(id, m.LeaseGrace)
+	}
+	if m.Metrics != nil {
+		m.Metrics.ObserveNode(id, node.BatteryKWh, node.CurrentLoadKW, node.IsOnline)
+	}
+	log.Printf("Registered Node: %s (%s)", id, nodeType)
+}
+
+func recordFor(n *Node) store.Record {
+	return store.Record{
+		ID:            n.ID,
+		Type:          n.Type,
+		BatteryKWh:    n.BatteryKWh,
+		CurrentLoadKW: n.CurrentLoadKW,
+		IsOnline:      n.IsOnline,
+		Endpoint:      n.Endpoint,
+	}
+}
+
+// RegisterDiscovered registers a node found via transport.Discover, filling
+// in the endpoint it was found at so operators don't have to call
+// RegisterNode by hand for every anchor/participant that powers on.
+func (m *MicrogridOrchestrator) RegisterDiscovered(ctx context.Context, d transport.Discovered, nodeType string) {
+	m.RegisterNode(ctx, d.ID, nodeType)
+	m.nodesMu.Lock()
+	m.Nodes[d.ID].Endpoint = d.Endpoint
+	m.nodesMu.Unlock()
+}
+
+// nodeViews snapshots the current nodes into the view type the dispatch
+// engine operates on, so the engine never holds a reference to live state.
+func (m *MicrogridOrchestrator) nodeViews() []dispatch.NodeView {
+	m.nodesMu.Lock()
+	defer m.nodesMu.Unlock()
+	views := make([]dispatch.NodeView, 0, len(m.Nodes))
+	for _, n := range m.Nodes {
+		if !n.IsOnline {
+			continue
+		}
+		views = append(views, dispatch.NodeView{
+			ID:            n.ID,
+			BatteryKWh:    n.BatteryKWh,
+			CurrentLoadKW: n.CurrentLoadKW,
+		})
+	}
+	return views
+}
+
+// Monitor runs the orchestrator's control loop. On every TickInterval it
+// computes and logs dispatch decisions (or just heartbeats if no dispatch
+// engine is set). In between ticks, if a transport is wired in, it selects
+// over every node's Telemetry channel and applies samples as they arrive
+// instead of sleeping.
+func (m *MicrogridOrchestrator) Monitor(ctx context.Context) error {
+	ticker := m.Clock.NewTicker(m.TickInterval)
+	defer ticker.Stop()
+
+	for {
+		const ( // fixed case indices before the dynamic per-node telemetry cases
+			caseDone = iota
+			caseTick
+			caseEviction
+			fixedCaseCount
+		)
+		cases := make([]reflect.SelectCase, fixedCaseCount)
+		cases[caseDone] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())}
+		cases[caseTick] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ticker.C())}
+		if m.Leases != nil {
+			cases[caseEviction] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(m.Leases.Evictions())}
+		} else {
+			cases[caseEviction] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(make(chan struct{}))}
+		}
+
+		nodeIDs := make([]string, fixedCaseCount)
+		m.nodesMu.Lock()
+		for id, n := range m.Nodes {
+			if n.Telemetry == nil {
+				continue
+			}
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(n.Telemetry)})
+			nodeIDs = append(nodeIDs, id)
+		}
+		m.nodesMu.Unlock()
+
+		chosen, recv, ok := reflect.Select(cases)
+		switch chosen {
+		case caseDone:
+			return ctx.Err()
+		case caseTick:
+			if err := m.tick(ctx); err != nil {
+				log.Printf("dispatch tick failed: %v", err)
+			}
+		case caseEviction:
+			m.evict(recv.Interface().(store.Eviction))
+		default:
+			if !ok {
+				// Node's telemetry channel closed (transport disconnect): drop
+				// the reference so it's excluded from cases on the next loop.
+				// Otherwise a closed channel is always select-ready and
+				// Monitor spins at 100% CPU re-selecting it forever.
+				m.nodesMu.Lock()
+				if n, found := m.Nodes[nodeIDs[chosen]]; found {
+					n.Telemetry = nil
+				}
+				m.nodesMu.Unlock()
+				continue
+			}
+			sample := recv.Interface().(transport.Telemetry)
+			m.applyTelemetry(ctx, nodeIDs[chosen], sample)
+		}
+	}
+}
+
+func (m *MicrogridOrchestrator) applyTelemetry(ctx context.Context, nodeID string, sample transport.Telemetry) {
+	ctx, span := telemetry.StartSpan(ctx, "applyTelemetry")
+	defer span.End()
+
+	m.nodesMu.Lock()
+	node, ok := m.Nodes[nodeID]
+	if ok {
+		node.BatteryKWh = sample.BatteryKWh
+		node.CurrentLoadKW = sample.CurrentLoadKW
+		node.LastSeen = sample.Timestamp
+	}
+	m.nodesMu.Unlock()
+	if !ok {
+		return
+	}
+	if m.Store != nil {
+		if err := m.Store.Put(ctx, recordFor(node)); err != nil {
+			log.Printf("store: persist node %s: %v", nodeID, err)
+		}
+	}
+	if m.Leases != nil {
+		m.Leases.Renew(nodeID, m.LeaseGrace)
+	}
+	if m.Metrics != nil {
+		m.Metrics.ObserveNode(nodeID, node.BatteryKWh, node.CurrentLoadKW, node.IsOnline)
+	}
+}
+
+// evict marks a node offline and drops it from the live node set after its
+// lease expires without a heartbeat.
+func (m *MicrogridOrchestrator) evict(ev store.Eviction) {
+	m.nodesMu.Lock()
+	node, ok := m.Nodes[ev.NodeID]
+	if ok {
+		node.IsOnline = false
+		delete(m.Nodes, ev.NodeID)
+	}
+	m.nodesMu.Unlock()
+	if !ok {
+		return
+	}
+	if m.Store != nil {
+		if err := m.Store.Delete(context.Background(), ev.NodeID); err != nil {
+			log.Printf("store: delete evicted node %s: %v", ev.NodeID, err)
+		}
+	}
+	if m.Metrics != nil {
+		m.Metrics.RemoveNode(ev.NodeID)
+		m.Metrics.NodeEvictionsTotal.Inc()
+	}
+	log.Printf("Evicted node %s (lease expired)", ev.NodeID)
+}
+
+// Snapshot serializes the current Store contents as JSON, for a peer anchor
+// to pull via Restore when it boots up cold.
+func (m *MicrogridOrchestrator) Snapshot(ctx context.Context) ([]byte, error) {
+	if m.Store == nil {
+		return nil, fmt.Errorf("orchestrator: no store configured")
+	}
+	records, err := m.Store.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("orchestrator: snapshot: %w", err)
+	}
+	b, err := json.Marshal(records)
+	if err != nil {
+		return nil, fmt.Errorf("orchestrator: marshal snapshot: %w", err)
+	}
+	return b, nil
+}
+
+// Restore reads a JSON-encoded snapshot produced by Snapshot and loads it
+// into both the Store and the in-memory node set, so a freshly booted
+// anchor can pull the current grid state from a peer instead of waiting to
+// rediscover every node.
+func (m *MicrogridOrchestrator) Restore(ctx context.Context, r io.Reader) error {
+	var records []store.Record
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return fmt.Errorf("orchestrator: decode snapshot: %w", err)
+	}
+	for _, rec := range records {
+		if m.Store != nil {
+			if err := m.Store.Put(ctx, rec); err != nil {
+				return fmt.Errorf("orchestrator: restore node %s: %w", rec.ID, err)
+			}
+		}
+		m.nodesMu.Lock()
+		m.Nodes[rec.ID] = &Node{
+			ID:            rec.ID,
+			Type:          rec.Type,
+			BatteryKWh:    rec.BatteryKWh,
+			CurrentLoadKW: rec.CurrentLoadKW,
+			IsOnline:      rec.IsOnline,
+			Endpoint:      rec.Endpoint,
+		}
+		m.nodesMu.Unlock()
+	}
+	return nil
+}
+
+func (m *MicrogridOrchestrator) tick(ctx context.Context) error {
+	ctx, span := telemetry.StartSpan(ctx, "DispatchTick")
+	defer span.End()
+
+	if m.Dispatch == nil {
+		log.Println("Orchestrator heartbeat...")
+		return nil
+	}
+	decisions, err := m.Dispatch.Tick(ctx, m.nodeViews())
+	if err != nil {
+		return err
+	}
+	for _, d := range decisions {
+		log.Printf("dispatch: %s -> %s at %.2fkW", d.Source, d.Sink, d.KW)
+		if m.Metrics != nil {
+			m.Metrics.DispatchDecisionsTotal.Inc()
+		}
+	}
+	return nil
+}
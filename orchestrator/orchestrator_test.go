@@ -0,0 +1,162 @@
+package orchestrator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Luk13Mad/StreetGridOS/orchestrator/clock"
+	"github.com/Luk13Mad/StreetGridOS/orchestrator/dispatch"
+	"github.com/Luk13Mad/StreetGridOS/orchestrator/telemetry"
+	"github.com/Luk13Mad/StreetGridOS/orchestrator/transport"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func newTestDispatchEngine(t *testing.T) (*dispatch.Engine, string) {
+	t.Helper()
+	f, err := os.CreateTemp("", "streetgrid-orchestrator-test-*.log")
+	if err != nil {
+		t.Fatalf("create temp log: %v", err)
+	}
+	path := f.Name()
+	f.Close()
+	t.Cleanup(func() { os.Remove(path) })
+
+	log, err := dispatch.OpenDecisionLog(path)
+	if err != nil {
+		t.Fatalf("open decision log: %v", err)
+	}
+	t.Cleanup(func() { log.Close() })
+
+	engine := dispatch.NewEngine("anchor_00", dispatch.ProportionalFairStrategy{SurplusThresholdKWh: 0.5}, log, nil)
+	return engine, path
+}
+
+// TestMonitorTicksOnFakeClockAdvance confirms Monitor only drives the
+// dispatch engine when its clock.Fake ticker is advanced, not on a real
+// timer, so the control loop can be tested step by step.
+func TestMonitorTicksOnFakeClockAdvance(t *testing.T) {
+	engine, logPath := newTestDispatchEngine(t)
+
+	m := NewOrchestrator()
+	m.SetDispatchEngine(engine)
+	m.TickInterval = time.Second
+	m.Nodes["anchor_00"] = &Node{ID: "anchor_00", IsOnline: true, BatteryKWh: 10, CurrentLoadKW: 0}
+	m.Nodes["participant_00"] = &Node{ID: "participant_00", IsOnline: true, BatteryKWh: 0, CurrentLoadKW: 5}
+
+	fakeClock := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	m.SetClock(fakeClock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- m.Monitor(ctx) }()
+
+	time.Sleep(20 * time.Millisecond)
+	before, err := dispatch.Bootstrap(logPath)
+	if err != nil {
+		t.Fatalf("bootstrap decision log: %v", err)
+	}
+	if len(before) != 0 {
+		t.Fatalf("expected no decisions committed before the clock advances, got %d", len(before))
+	}
+
+	fakeClock.Advance(m.TickInterval)
+	time.Sleep(50 * time.Millisecond)
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("Monitor returned %v, want context.Canceled", err)
+	}
+
+	after, err := dispatch.Bootstrap(logPath)
+	if err != nil {
+		t.Fatalf("bootstrap decision log: %v", err)
+	}
+	if len(after) == 0 {
+		t.Fatal("expected Monitor to have committed a decision after the ticker fired")
+	}
+}
+
+// TestMonitorDropsClosedTelemetryChannel confirms that when a node's
+// telemetry channel closes, Monitor clears the node's Telemetry reference
+// instead of leaving it in cases forever. Before this fix a closed recv
+// channel is always select-ready, so Monitor would spin at 100% CPU
+// re-selecting it on every loop instead of dropping the node.
+func TestMonitorDropsClosedTelemetryChannel(t *testing.T) {
+	m := NewOrchestrator()
+	m.TickInterval = time.Hour // keep the ticker out of the way
+
+	telemetryCh := make(chan transport.Telemetry, 1)
+	m.Nodes["participant_00"] = &Node{ID: "participant_00", IsOnline: true, Telemetry: telemetryCh}
+
+	fakeClock := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	m.SetClock(fakeClock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- m.Monitor(ctx) }()
+
+	telemetryCh <- transport.Telemetry{NodeID: "participant_00", BatteryKWh: 3.5, CurrentLoadKW: 1.5, Timestamp: time.Now()}
+	time.Sleep(20 * time.Millisecond)
+	close(telemetryCh)
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("Monitor returned %v, want context.Canceled", err)
+	}
+
+	node := m.Nodes["participant_00"]
+	if node.BatteryKWh != 3.5 {
+		t.Fatalf("telemetry sample before close should have been applied, got BatteryKWh=%v", node.BatteryKWh)
+	}
+	if node.Telemetry != nil {
+		t.Fatal("Monitor should have cleared Telemetry once the channel closed, instead of re-selecting it forever")
+	}
+}
+
+// TestSetMetricsBeforeDispatchEngineWiresOnElection confirms OnElection gets
+// wired up even when SetMetrics is called before SetDispatchEngine. Wiring
+// it only from SetMetrics would silently drop election_terms_total whenever
+// callers set them up in this order.
+func TestSetMetricsBeforeDispatchEngineWiresOnElection(t *testing.T) {
+	engine, _ := newTestDispatchEngine(t)
+	metrics := telemetry.NewMetrics()
+
+	m := NewOrchestrator()
+	m.SetMetrics(metrics)
+	m.SetDispatchEngine(engine)
+
+	if engine.OnElection == nil {
+		t.Fatal("OnElection should be wired once both Metrics and Dispatch are set, regardless of call order")
+	}
+	engine.OnElection(1)
+	if got := testutil.ToFloat64(metrics.ElectionTermsTotal); got != 1 {
+		t.Fatalf("election_terms_total = %v, want 1", got)
+	}
+}
+
+// TestRegisterDiscoveredConcurrentWithMonitor registers discovered nodes
+// from a separate goroutine while Monitor's control loop is ranging over
+// Nodes, the way live mDNS discovery runs alongside Monitor in runServe.
+// Run with -race: before nodesMu this was a concurrent map write against an
+// active range and would be flagged (or panic outright).
+func TestRegisterDiscoveredConcurrentWithMonitor(t *testing.T) {
+	m := NewOrchestrator()
+	m.TickInterval = time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- m.Monitor(ctx) }()
+
+	for i := 0; i < 50; i++ {
+		id := "participant_" + string(rune('a'+i%26))
+		m.RegisterDiscovered(ctx, transport.Discovered{ID: id, Endpoint: "10.0.0.1:7001"}, "participant")
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("Monitor returned %v, want context.Canceled", err)
+	}
+}
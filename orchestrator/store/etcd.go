@@ -0,0 +1,80 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// etcdKeyPrefix namespaces StreetGridOS's keys in a shared etcd cluster.
+const etcdKeyPrefix = "/streetgrid/nodes/"
+
+// EtcdStore backs the grid state with etcd, for multi-anchor HA
+// deployments where every anchor needs a consistent view of the grid.
+type EtcdStore struct {
+	client *clientv3.Client
+}
+
+// OpenEtcdStore dials the etcd cluster at the given endpoints.
+func OpenEtcdStore(endpoints []string) (*EtcdStore, error) {
+	client, err := clientv3.New(clientv3.Config{Endpoints: endpoints})
+	if err != nil {
+		return nil, fmt.Errorf("store: dial etcd: %w", err)
+	}
+	return &EtcdStore{client: client}, nil
+}
+
+func (s *EtcdStore) Put(ctx context.Context, rec Record) error {
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("store: marshal record: %w", err)
+	}
+	if _, err := s.client.Put(ctx, etcdKeyPrefix+rec.ID, string(b)); err != nil {
+		return fmt.Errorf("store: put record %s: %w", rec.ID, err)
+	}
+	return nil
+}
+
+func (s *EtcdStore) Get(ctx context.Context, id string) (Record, bool, error) {
+	resp, err := s.client.Get(ctx, etcdKeyPrefix+id)
+	if err != nil {
+		return Record{}, false, fmt.Errorf("store: get record %s: %w", id, err)
+	}
+	if len(resp.Kvs) == 0 {
+		return Record{}, false, nil
+	}
+	var rec Record
+	if err := json.Unmarshal(resp.Kvs[0].Value, &rec); err != nil {
+		return Record{}, false, fmt.Errorf("store: unmarshal record %s: %w", id, err)
+	}
+	return rec, true, nil
+}
+
+func (s *EtcdStore) Delete(ctx context.Context, id string) error {
+	if _, err := s.client.Delete(ctx, etcdKeyPrefix+id); err != nil {
+		return fmt.Errorf("store: delete record %s: %w", id, err)
+	}
+	return nil
+}
+
+func (s *EtcdStore) List(ctx context.Context) ([]Record, error) {
+	resp, err := s.client.Get(ctx, etcdKeyPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("store: list records: %w", err)
+	}
+	out := make([]Record, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		var rec Record
+		if err := json.Unmarshal(kv.Value, &rec); err != nil {
+			return nil, fmt.Errorf("store: unmarshal record: %w", err)
+		}
+		out = append(out, rec)
+	}
+	return out, nil
+}
+
+func (s *EtcdStore) Close() error {
+	return s.client.Close()
+}
@@ -0,0 +1,78 @@
+package store
+
+import (
+	"sync"
+	"time"
+)
+
+// Eviction is published when a node's lease expires without being renewed.
+type Eviction struct {
+	NodeID string
+	At     time.Time
+}
+
+// LeaseManager tracks a TTL per participant registration, mirroring etcd's
+// lease semantics: a node must be kept alive (Renew) within its grace
+// period or it is considered gone and published on Evictions.
+type LeaseManager struct {
+	mu        sync.Mutex
+	timers    map[string]*time.Timer
+	evictions chan Eviction
+}
+
+// NewLeaseManager builds a lease manager. The Evictions channel is buffered
+// so a slow consumer doesn't stall eviction timers firing.
+func NewLeaseManager() *LeaseManager {
+	return &LeaseManager{
+		timers:    make(map[string]*time.Timer),
+		evictions: make(chan Eviction, 32),
+	}
+}
+
+// Register arms a lease for nodeID that fires after grace if not renewed.
+// Re-registering an already-leased node replaces its timer.
+func (lm *LeaseManager) Register(nodeID string, grace time.Duration) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	lm.armLocked(nodeID, grace)
+}
+
+// Renew resets nodeID's lease timer to grace from now, as if a fresh
+// heartbeat had just been received. It is a no-op if nodeID has no lease.
+func (lm *LeaseManager) Renew(nodeID string, grace time.Duration) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	if _, ok := lm.timers[nodeID]; !ok {
+		return
+	}
+	lm.armLocked(nodeID, grace)
+}
+
+func (lm *LeaseManager) armLocked(nodeID string, grace time.Duration) {
+	if t, ok := lm.timers[nodeID]; ok {
+		t.Stop()
+	}
+	lm.timers[nodeID] = time.AfterFunc(grace, func() {
+		lm.mu.Lock()
+		delete(lm.timers, nodeID)
+		lm.mu.Unlock()
+		lm.evictions <- Eviction{NodeID: nodeID, At: time.Now()}
+	})
+}
+
+// Cancel stops nodeID's lease without publishing an eviction, e.g. when the
+// node is deregistered cleanly.
+func (lm *LeaseManager) Cancel(nodeID string) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	if t, ok := lm.timers[nodeID]; ok {
+		t.Stop()
+		delete(lm.timers, nodeID)
+	}
+}
+
+// Evictions returns the channel nodes are published on when their lease
+// expires.
+func (lm *LeaseManager) Evictions() <-chan Eviction {
+	return lm.evictions
+}
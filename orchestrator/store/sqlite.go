@@ -0,0 +1,99 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+)
+
+// SQLiteStore backs the grid state with SQLite, for deployments that want
+// to audit or query node history with plain SQL rather than just the
+// latest snapshot.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// OpenSQLiteStore opens (creating if necessary) a SQLite database at path
+// and ensures the nodes table exists.
+func OpenSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("store: open sqlite db: %w", err)
+	}
+	const schema = `
+	CREATE TABLE IF NOT EXISTS nodes (
+		id              TEXT PRIMARY KEY,
+		type            TEXT NOT NULL,
+		battery_kwh     REAL NOT NULL,
+		current_load_kw REAL NOT NULL,
+		is_online       INTEGER NOT NULL,
+		endpoint        TEXT NOT NULL
+	)`
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("store: create nodes table: %w", err)
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+func (s *SQLiteStore) Put(ctx context.Context, rec Record) error {
+	const q = `
+	INSERT INTO nodes (id, type, battery_kwh, current_load_kw, is_online, endpoint)
+	VALUES (?, ?, ?, ?, ?, ?)
+	ON CONFLICT(id) DO UPDATE SET
+		type = excluded.type,
+		battery_kwh = excluded.battery_kwh,
+		current_load_kw = excluded.current_load_kw,
+		is_online = excluded.is_online,
+		endpoint = excluded.endpoint`
+	_, err := s.db.ExecContext(ctx, q, rec.ID, rec.Type, rec.BatteryKWh, rec.CurrentLoadKW, rec.IsOnline, rec.Endpoint)
+	if err != nil {
+		return fmt.Errorf("store: put record %s: %w", rec.ID, err)
+	}
+	return nil
+}
+
+func (s *SQLiteStore) Get(ctx context.Context, id string) (Record, bool, error) {
+	const q = `SELECT id, type, battery_kwh, current_load_kw, is_online, endpoint FROM nodes WHERE id = ?`
+	var rec Record
+	err := s.db.QueryRowContext(ctx, q, id).Scan(&rec.ID, &rec.Type, &rec.BatteryKWh, &rec.CurrentLoadKW, &rec.IsOnline, &rec.Endpoint)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Record{}, false, nil
+	}
+	if err != nil {
+		return Record{}, false, fmt.Errorf("store: get record %s: %w", id, err)
+	}
+	return rec, true, nil
+}
+
+func (s *SQLiteStore) Delete(ctx context.Context, id string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM nodes WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("store: delete record %s: %w", id, err)
+	}
+	return nil
+}
+
+func (s *SQLiteStore) List(ctx context.Context) ([]Record, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, type, battery_kwh, current_load_kw, is_online, endpoint FROM nodes`)
+	if err != nil {
+		return nil, fmt.Errorf("store: list records: %w", err)
+	}
+	defer rows.Close()
+
+	var out []Record
+	for rows.Next() {
+		var rec Record
+		if err := rows.Scan(&rec.ID, &rec.Type, &rec.BatteryKWh, &rec.CurrentLoadKW, &rec.IsOnline, &rec.Endpoint); err != nil {
+			return nil, fmt.Errorf("store: scan record: %w", err)
+		}
+		out = append(out, rec)
+	}
+	return out, rows.Err()
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,29 @@
+// Package store persists microgrid node state behind a single interface,
+// with swappable backends: BoltDB for a single-anchor deployment, SQLite
+// when auditing/querying the grid's history matters, and etcd for
+// multi-anchor HA deployments that already run etcd for leader election.
+package store
+
+import "context"
+
+// Record is the persisted view of a node. Runtime-only state (streaming
+// channels, in-flight telemetry) lives on orchestrator.Node and never
+// reaches the store.
+type Record struct {
+	ID            string  `json:"id"`
+	Type          string  `json:"type"`
+	BatteryKWh    float64 `json:"battery_kwh"`
+	CurrentLoadKW float64 `json:"current_load_kw"`
+	IsOnline      bool    `json:"is_online"`
+	Endpoint      string  `json:"endpoint"`
+}
+
+// StateStore persists node Records. Implementations must be safe for
+// concurrent use.
+type StateStore interface {
+	Put(ctx context.Context, rec Record) error
+	Get(ctx context.Context, id string) (Record, bool, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context) ([]Record, error)
+	Close() error
+}
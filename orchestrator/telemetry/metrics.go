@@ -0,0 +1,84 @@
+package telemetry
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Metrics holds every Prometheus collector the orchestrator reports, wired
+// into its own registry so MetricsServer never pulls in the process-wide
+// default one.
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	BatteryKWh    *prometheus.GaugeVec
+	CurrentLoadKW *prometheus.GaugeVec
+	Online        *prometheus.GaugeVec
+
+	DispatchDecisionsTotal prometheus.Counter
+	NodeEvictionsTotal     prometheus.Counter
+	ElectionTermsTotal     prometheus.Counter
+}
+
+// NewMetrics registers every collector against a fresh registry and returns
+// the handle the orchestrator updates as it runs.
+func NewMetrics() *Metrics {
+	reg := prometheus.NewRegistry()
+
+	m := &Metrics{
+		Registry: reg,
+		BatteryKWh: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "battery_kwh",
+			Help: "Current battery state of charge, in kWh, per node.",
+		}, []string{"node_id"}),
+		CurrentLoadKW: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "current_load_kw",
+			Help: "Current load, in kW, per node.",
+		}, []string{"node_id"}),
+		Online: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "online",
+			Help: "1 if the node is online, 0 otherwise.",
+		}, []string{"node_id"}),
+		DispatchDecisionsTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "dispatch_decisions_total",
+			Help: "Total number of dispatch decisions committed by the leader.",
+		}),
+		NodeEvictionsTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "node_evictions_total",
+			Help: "Total number of nodes evicted for an expired lease.",
+		}),
+		ElectionTermsTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "election_terms_total",
+			Help: "Total number of dispatch leader election terms started.",
+		}),
+	}
+
+	reg.MustRegister(
+		m.BatteryKWh,
+		m.CurrentLoadKW,
+		m.Online,
+		m.DispatchDecisionsTotal,
+		m.NodeEvictionsTotal,
+		m.ElectionTermsTotal,
+	)
+	return m
+}
+
+// boolToFloat converts IsOnline into the 0/1 the online gauge expects.
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// ObserveNode updates the per-node gauges for id.
+func (m *Metrics) ObserveNode(id string, batteryKWh, currentLoadKW float64, online bool) {
+	m.BatteryKWh.WithLabelValues(id).Set(batteryKWh)
+	m.CurrentLoadKW.WithLabelValues(id).Set(currentLoadKW)
+	m.Online.WithLabelValues(id).Set(boolToFloat(online))
+}
+
+// RemoveNode drops id's gauges, e.g. after an eviction.
+func (m *Metrics) RemoveNode(id string) {
+	m.BatteryKWh.DeleteLabelValues(id)
+	m.CurrentLoadKW.DeleteLabelValues(id)
+	m.Online.DeleteLabelValues(id)
+}
@@ -0,0 +1,51 @@
+// Package telemetry exposes the orchestrator's Prometheus metrics over
+// HTTP and provides the OpenTelemetry tracer used to instrument a dispatch
+// decision from telemetry ingest through to actuation.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// MetricsServer serves /metrics in Prometheus text format over HTTP(S).
+type MetricsServer struct {
+	metrics *Metrics
+	server  *http.Server
+}
+
+// NewMetricsServer builds a server bound to addr (e.g. ":9090") exposing
+// metrics. If certFile/keyFile are both non-empty, ListenAndServe serves
+// TLS; otherwise it serves plain HTTP.
+func NewMetricsServer(addr string, metrics *Metrics) *MetricsServer {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{}))
+
+	return &MetricsServer{
+		metrics: metrics,
+		server:  &http.Server{Addr: addr, Handler: mux},
+	}
+}
+
+// ListenAndServe starts the HTTP(S) server and blocks until it exits. If
+// certFile and keyFile are both set it serves TLS, otherwise plain HTTP.
+func (s *MetricsServer) ListenAndServe(certFile, keyFile string) error {
+	if certFile != "" && keyFile != "" {
+		if err := s.server.ListenAndServeTLS(certFile, keyFile); err != nil && err != http.ErrServerClosed {
+			return fmt.Errorf("telemetry: serve metrics over tls: %w", err)
+		}
+		return nil
+	}
+	if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("telemetry: serve metrics: %w", err)
+	}
+	return nil
+}
+
+// Shutdown gracefully stops the metrics server.
+func (s *MetricsServer) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
@@ -0,0 +1,51 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName identifies StreetGridOS spans in whatever backend the exporter
+// ships them to.
+const TracerName = "streetgridos/orchestrator"
+
+// NewTracerProvider builds a TracerProvider that writes spans as JSON to w.
+// Swap the exporter here for an OTLP one once a collector endpoint exists;
+// callers only depend on the trace.TracerProvider interface.
+func NewTracerProvider(w io.Writer) (*sdktrace.TracerProvider, error) {
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(w))
+	if err != nil {
+		return nil, fmt.Errorf("telemetry: build trace exporter: %w", err)
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName("streetgridos-orchestrator"),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("telemetry: build trace resource: %w", err)
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	return tp, nil
+}
+
+// Tracer returns the orchestrator's tracer from whatever TracerProvider is
+// currently registered globally (otel.SetTracerProvider).
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// StartSpan is a small convenience wrapper so call sites read as
+// `ctx, span := telemetry.StartSpan(ctx, "RegisterNode")`.
+func StartSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name)
+}
@@ -0,0 +1,29 @@
+package transport
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// jsonCodecName is the content-subtype this codec is registered under.
+// StreetGridOS anchors only ever talk to each other, so there is no interop
+// requirement with real protobuf wire format, and JSON keeps the gRPC
+// transport self-contained without a protoc code-generation step. It is
+// deliberately not named "proto": that's gRPC's default codec, and
+// overriding it process-wide would silently break any other gRPC
+// client/server sharing this binary.
+const jsonCodecName = "streetgridjson"
+
+// jsonCodec implements grpc/encoding.Codec.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+func (jsonCodec) Name() string { return jsonCodecName }
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
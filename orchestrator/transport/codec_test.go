@@ -0,0 +1,28 @@
+package transport
+
+import "testing"
+
+// TestJSONCodecRoundTrip confirms jsonCodec marshals and unmarshals a
+// Telemetry sample losslessly, and is registered under its own name rather
+// than shadowing grpc's default "proto" codec.
+func TestJSONCodecRoundTrip(t *testing.T) {
+	if got, want := (jsonCodec{}).Name(), jsonCodecName; got != want {
+		t.Fatalf("jsonCodec.Name() = %q, want %q", got, want)
+	}
+	if jsonCodecName == "proto" {
+		t.Fatal("jsonCodec must not register under grpc's default \"proto\" codec name")
+	}
+
+	want := Telemetry{NodeID: "anchor_00", BatteryKWh: 12.5, CurrentLoadKW: 3.25}
+	b, err := (jsonCodec{}).Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var got Telemetry
+	if err := (jsonCodec{}).Unmarshal(b, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got != want {
+		t.Fatalf("round trip mismatch: got %+v, want %+v", got, want)
+	}
+}
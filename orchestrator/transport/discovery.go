@@ -0,0 +1,64 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/mdns"
+)
+
+// ServiceName is the DNS-SD service type anchors advertise themselves under.
+const ServiceName = "_streetgrid._tcp"
+
+// Advertise announces id as a StreetGridOS anchor reachable at port over
+// mDNS/DNS-SD, so participants and other anchors can find it without a
+// manually configured address. The returned func shuts the advertisement
+// down.
+func Advertise(id string, port int) (shutdown func(), err error) {
+	info := []string{"streetgrid anchor"}
+	service, err := mdns.NewMDNSService(id, ServiceName, "", "", port, nil, info)
+	if err != nil {
+		return nil, fmt.Errorf("transport: build mdns service: %w", err)
+	}
+	server, err := mdns.NewServer(&mdns.Config{Zone: service})
+	if err != nil {
+		return nil, fmt.Errorf("transport: start mdns server: %w", err)
+	}
+	return func() { server.Shutdown() }, nil
+}
+
+// Discover browses for anchors/participants advertising ServiceName and
+// sends each one found on the returned channel. It stops browsing and
+// closes the channel when ctx is cancelled.
+func Discover(ctx context.Context) (<-chan Discovered, error) {
+	entries := make(chan *mdns.ServiceEntry, 16)
+	out := make(chan Discovered, 16)
+
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case entry, ok := <-entries:
+				if !ok {
+					return
+				}
+				out <- Discovered{
+					ID:       entry.Name,
+					Endpoint: fmt.Sprintf("%s:%d", entry.AddrV4, entry.Port),
+				}
+			}
+		}
+	}()
+
+	go func() {
+		defer close(entries)
+		_ = mdns.Query(&mdns.QueryParam{
+			Service: ServiceName,
+			Entries: entries,
+		})
+	}()
+
+	return out, nil
+}
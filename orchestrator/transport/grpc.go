@@ -0,0 +1,113 @@
+package transport
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// telemetryServiceDesc describes a single bidirectional-streaming RPC,
+// "Push", that a node uses to stream Telemetry samples to an anchor. It is
+// hand-written rather than protoc-generated (see codec.go); the "node-id"
+// request header tells the server which node's channel to feed.
+var telemetryServiceDesc = grpc.ServiceDesc{
+	ServiceName: "streetgrid.Telemetry",
+	HandlerType: (*any)(nil),
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Push",
+			Handler:       telemetryPushHandler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+}
+
+// GRPCTransport receives telemetry pushed by anchors over a persistent
+// bidirectional gRPC stream.
+type GRPCTransport struct {
+	mu       sync.Mutex
+	channels map[string]chan Telemetry
+	server   *grpc.Server
+}
+
+// NewGRPCTransport builds a transport ready to Serve. It forces every call
+// through jsonCodec regardless of the client's negotiated content-subtype,
+// rather than relying on the "proto" name grpc falls back to by default, so
+// this codec stays scoped to this server instead of shadowing real protobuf
+// for any other gRPC user in the same process.
+func NewGRPCTransport() *GRPCTransport {
+	t := &GRPCTransport{channels: make(map[string]chan Telemetry)}
+	t.server = grpc.NewServer(grpc.ForceServerCodec(jsonCodec{}))
+	t.server.RegisterService(&telemetryServiceDesc, t)
+	return t
+}
+
+// Serve accepts connections on addr and blocks until Close is called.
+func (t *GRPCTransport) Serve(addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("transport: listen %s: %w", addr, err)
+	}
+	return t.server.Serve(lis)
+}
+
+func (t *GRPCTransport) channelFor(nodeID string) chan Telemetry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ch, ok := t.channels[nodeID]
+	if !ok {
+		ch = make(chan Telemetry, 16)
+		t.channels[nodeID] = ch
+	}
+	return ch
+}
+
+// Telemetry implements NodeTransport.
+func (t *GRPCTransport) Telemetry(nodeID string) <-chan Telemetry {
+	return t.channelFor(nodeID)
+}
+
+// Close implements NodeTransport.
+func (t *GRPCTransport) Close() error {
+	t.server.GracefulStop()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, ch := range t.channels {
+		close(ch)
+	}
+	return nil
+}
+
+// telemetryPushHandler reads the "node-id" header off the incoming stream
+// and forwards every Telemetry message received to that node's channel
+// until the client closes the stream.
+func telemetryPushHandler(srv interface{}, stream grpc.ServerStream) error {
+	t := srv.(*GRPCTransport)
+
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	ids := md.Get("node-id")
+	if len(ids) == 0 {
+		return fmt.Errorf("transport: push stream missing node-id header")
+	}
+	ch := t.channelFor(ids[0])
+
+	for {
+		var sample Telemetry
+		if err := stream.RecvMsg(&sample); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if sample.Timestamp.IsZero() {
+			sample.Timestamp = time.Now()
+		}
+		ch <- sample
+	}
+}
@@ -0,0 +1,97 @@
+package transport
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// telemetryTopicFilter subscribes to every node's telemetry topic at once;
+// the node ID is the wildcard segment.
+const telemetryTopicFilter = "streetgrid/+/telemetry"
+
+// MQTTTransport receives telemetry from constrained participant devices
+// that publish JSON-encoded Telemetry samples over MQTT rather than holding
+// a gRPC stream open.
+type MQTTTransport struct {
+	mu       sync.Mutex
+	channels map[string]chan Telemetry
+	client   mqtt.Client
+}
+
+// NewMQTTTransport connects to the broker at brokerURL (e.g.
+// "tcp://localhost:1883") and subscribes to every node's telemetry topic.
+func NewMQTTTransport(brokerURL string) (*MQTTTransport, error) {
+	t := &MQTTTransport{channels: make(map[string]chan Telemetry)}
+
+	opts := mqtt.NewClientOptions().
+		AddBroker(brokerURL).
+		SetClientID("streetgrid-orchestrator").
+		SetAutoReconnect(true)
+	t.client = mqtt.NewClient(opts)
+
+	if token := t.client.Connect(); token.Wait() && token.Error() != nil {
+		return nil, fmt.Errorf("transport: connect to mqtt broker %s: %w", brokerURL, token.Error())
+	}
+	if token := t.client.Subscribe(telemetryTopicFilter, 1, t.onMessage); token.Wait() && token.Error() != nil {
+		return nil, fmt.Errorf("transport: subscribe %s: %w", telemetryTopicFilter, token.Error())
+	}
+	return t, nil
+}
+
+func (t *MQTTTransport) onMessage(_ mqtt.Client, msg mqtt.Message) {
+	nodeID := nodeIDFromTopic(msg.Topic())
+	if nodeID == "" {
+		return
+	}
+	var sample Telemetry
+	if err := json.Unmarshal(msg.Payload(), &sample); err != nil {
+		return
+	}
+	sample.NodeID = nodeID
+	if sample.Timestamp.IsZero() {
+		sample.Timestamp = time.Now()
+	}
+	t.channelFor(nodeID) <- sample
+}
+
+// nodeIDFromTopic extracts the wildcard segment out of a
+// "streetgrid/<id>/telemetry" topic.
+func nodeIDFromTopic(topic string) string {
+	parts := strings.Split(topic, "/")
+	if len(parts) != 3 || parts[0] != "streetgrid" || parts[2] != "telemetry" {
+		return ""
+	}
+	return parts[1]
+}
+
+func (t *MQTTTransport) channelFor(nodeID string) chan Telemetry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ch, ok := t.channels[nodeID]
+	if !ok {
+		ch = make(chan Telemetry, 16)
+		t.channels[nodeID] = ch
+	}
+	return ch
+}
+
+// Telemetry implements NodeTransport.
+func (t *MQTTTransport) Telemetry(nodeID string) <-chan Telemetry {
+	return t.channelFor(nodeID)
+}
+
+// Close implements NodeTransport.
+func (t *MQTTTransport) Close() error {
+	t.client.Disconnect(250)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, ch := range t.channels {
+		close(ch)
+	}
+	return nil
+}
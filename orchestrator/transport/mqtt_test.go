@@ -0,0 +1,22 @@
+package transport
+
+import "testing"
+
+func TestNodeIDFromTopic(t *testing.T) {
+	tests := []struct {
+		topic string
+		want  string
+	}{
+		{"streetgrid/participant_01/telemetry", "participant_01"},
+		{"streetgrid/anchor_00/telemetry", "anchor_00"},
+		{"streetgrid/telemetry", ""},
+		{"other/participant_01/telemetry", ""},
+		{"streetgrid/participant_01/status", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := nodeIDFromTopic(tt.topic); got != tt.want {
+			t.Errorf("nodeIDFromTopic(%q) = %q, want %q", tt.topic, got, tt.want)
+		}
+	}
+}
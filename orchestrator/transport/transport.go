@@ -0,0 +1,34 @@
+// Package transport provides pluggable ways for the orchestrator to talk to
+// anchors and participants: gRPC for anchors that can hold a persistent
+// stream, MQTT for constrained participant devices, and mDNS/DNS-SD for
+// discovering both without a manual RegisterNode call.
+package transport
+
+import "time"
+
+// Telemetry is a single sample pushed by a node.
+type Telemetry struct {
+	NodeID        string
+	BatteryKWh    float64
+	CurrentLoadKW float64
+	Timestamp     time.Time
+}
+
+// Discovered is a node found via mDNS/DNS-SD before it has ever pushed
+// telemetry.
+type Discovered struct {
+	ID       string
+	Endpoint string
+}
+
+// NodeTransport is how the orchestrator receives telemetry from nodes. Each
+// node gets its own channel so Monitor can select over all of them rather
+// than polling; the channel is closed when the node disconnects.
+type NodeTransport interface {
+	// Telemetry returns the channel telemetry for nodeID arrives on,
+	// creating it if this is the first time nodeID has been seen.
+	Telemetry(nodeID string) <-chan Telemetry
+
+	// Close shuts the transport down and closes every node channel it owns.
+	Close() error
+}
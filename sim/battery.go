@@ -0,0 +1,34 @@
+package sim
+
+import "time"
+
+// Battery models a single node's charge/discharge dynamics: solar
+// generation charges it, load discharges it, clamped to its capacity.
+type Battery struct {
+	CapacityKWh float64
+	SoCKWh      float64
+}
+
+// Step advances the battery by interval given solarKW generated and loadKW
+// drawn, and returns the net load the node presents to the grid: positive
+// if it still needs power after using its own generation and battery,
+// negative if it has surplus to offer.
+func (b *Battery) Step(interval time.Duration, solarKW, loadKW float64) (netLoadKW float64) {
+	hours := interval.Hours()
+	net := loadKW - solarKW // positive: drawing more than generating
+	b.SoCKWh -= net * hours
+
+	switch {
+	case b.SoCKWh > b.CapacityKWh:
+		// Excess beyond a full battery is curtailed (no grid export here);
+		// the node presents as a full-SoC surplus node instead.
+		b.SoCKWh = b.CapacityKWh
+	case b.SoCKWh < 0:
+		// Battery couldn't cover the deficit on its own; the shortfall is
+		// what the node needs the dispatch engine to cover.
+		shortfall := -b.SoCKWh
+		b.SoCKWh = 0
+		return shortfall
+	}
+	return 0
+}
@@ -0,0 +1,24 @@
+package sim
+
+import (
+	"context"
+
+	"github.com/Luk13Mad/StreetGridOS/orchestrator/dispatch"
+)
+
+// inProcessPeer lets simulated anchors run the real election/replication
+// protocol against each other in-process, with no network in between.
+type inProcessPeer struct {
+	id     string
+	engine *dispatch.Engine
+}
+
+func (p *inProcessPeer) ID() string { return p.id }
+
+func (p *inProcessPeer) RequestVote(_ context.Context, req dispatch.VoteRequest) (dispatch.VoteResponse, error) {
+	return p.engine.HandleVoteRequest(req), nil
+}
+
+func (p *inProcessPeer) Replicate(_ context.Context, entry dispatch.LogEntry) error {
+	return p.engine.Apply(entry)
+}
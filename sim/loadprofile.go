@@ -0,0 +1,52 @@
+package sim
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// LoadProfile is a step-indexed series of load values in kW, read from a
+// "step,load_kw" CSV and repeating once exhausted.
+type LoadProfile []float64
+
+// flatLoadProfile is used when a scenario doesn't supply a CSV.
+const flatLoadKW = 1.2
+
+// At returns the load for the given step, wrapping around if the profile is
+// shorter than the simulation.
+func (p LoadProfile) At(step int) float64 {
+	if len(p) == 0 {
+		return flatLoadKW
+	}
+	return p[step%len(p)]
+}
+
+// LoadLoadProfile reads a "step,load_kw" CSV (with or without a header row)
+// into a LoadProfile ordered by step.
+func LoadLoadProfile(path string) (LoadProfile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("sim: open load profile %s: %w", path, err)
+	}
+	defer f.Close()
+
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("sim: parse load profile %s: %w", path, err)
+	}
+
+	var profile LoadProfile
+	for _, row := range records {
+		if len(row) < 2 {
+			continue
+		}
+		kw, err := strconv.ParseFloat(row[1], 64)
+		if err != nil {
+			continue // header row or malformed line; skip rather than fail the whole run
+		}
+		profile = append(profile, kw)
+	}
+	return profile, nil
+}
@@ -0,0 +1,10 @@
+package sim
+
+// Report summarizes a completed simulation run, for comparing dispatch
+// strategies or validating a scenario against a real hardware rollout.
+type Report struct {
+	EnergyServedKWh       float64
+	UnservedEnergyPercent float64
+	AverageSoCPerNode     map[string]float64
+	LeaderChanges         int
+}
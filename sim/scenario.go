@@ -0,0 +1,88 @@
+// Package sim models a synthetic street of anchors and participants so the
+// dispatch engine can be exercised against many hours of simulated grid
+// activity before rolling code out to real hardware.
+package sim
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Scenario describes a synthetic street: how many anchors and participants
+// to simulate, their battery characteristics, and where to read their solar
+// and load profiles from.
+type Scenario struct {
+	Participants int           `yaml:"participants"`
+	Anchors      int           `yaml:"anchors"`
+	Duration     time.Duration `yaml:"duration"`
+	StepInterval time.Duration `yaml:"step_interval"`
+
+	// BatteryCapacityKWh is the full battery capacity every simulated node
+	// starts with.
+	BatteryCapacityKWh float64 `yaml:"battery_capacity_kwh"`
+	// InitialSoC is the fraction (0-1) of BatteryCapacityKWh every node
+	// starts charged to.
+	InitialSoC float64 `yaml:"initial_soc"`
+
+	// SolarIrradianceProfile is a fixed-length, repeating curve (0-1) of
+	// how much of a panel's rated output is available at each step,
+	// indexed by (step % len(profile)).
+	SolarIrradianceProfile []float64 `yaml:"solar_irradiance_profile"`
+	// SolarCapacityKW is each node's panel rating.
+	SolarCapacityKW float64 `yaml:"solar_capacity_kw"`
+
+	// LoadProfileCSV is a path to a CSV of "step,load_kw" rows applied to
+	// every participant. If empty, a flat default load is used.
+	LoadProfileCSV string `yaml:"load_profile_csv"`
+}
+
+// LoadScenario reads and parses a YAML scenario file at path.
+func LoadScenario(path string) (Scenario, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Scenario{}, fmt.Errorf("sim: read scenario %s: %w", path, err)
+	}
+	var s Scenario
+	if err := yaml.Unmarshal(b, &s); err != nil {
+		return Scenario{}, fmt.Errorf("sim: parse scenario %s: %w", path, err)
+	}
+	s.applyDefaults()
+	return s, nil
+}
+
+func (s *Scenario) applyDefaults() {
+	if s.StepInterval == 0 {
+		s.StepInterval = time.Minute
+	}
+	if s.BatteryCapacityKWh == 0 {
+		s.BatteryCapacityKWh = 13.5
+	}
+	if s.InitialSoC == 0 {
+		s.InitialSoC = 0.5
+	}
+	if s.SolarCapacityKW == 0 {
+		s.SolarCapacityKW = 4.0
+	}
+	if len(s.SolarIrradianceProfile) == 0 {
+		s.SolarIrradianceProfile = defaultSolarProfile()
+	}
+}
+
+// defaultSolarProfile is a simple 24-hourly-step daylight curve peaking at
+// noon, used when a scenario doesn't supply its own.
+func defaultSolarProfile() []float64 {
+	profile := make([]float64, 24)
+	for h := range profile {
+		// Zero from 8pm-6am, a sine hump peaking at noon otherwise.
+		if h < 6 || h >= 20 {
+			continue
+		}
+		x := float64(h-6) / 14.0 // 0 at 6am, 1 at 8pm
+		profile[h] = math.Sin(math.Pi * x)
+	}
+	return profile
+}
@@ -0,0 +1,231 @@
+package sim
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Luk13Mad/StreetGridOS/orchestrator/clock"
+	"github.com/Luk13Mad/StreetGridOS/orchestrator/dispatch"
+)
+
+// simNode is one simulated participant or anchor: its dispatch-facing view
+// plus the battery driving it.
+type simNode struct {
+	id              string
+	isAnchor        bool
+	battery         *Battery
+	solarCapacityKW float64
+}
+
+// anchorEngine pairs a simulated anchor with the dispatch engine it runs,
+// so the world can rotate leadership and tally leader changes.
+type anchorEngine struct {
+	node   *simNode
+	engine *dispatch.Engine
+	log    *dispatch.DecisionLog
+	path   string
+}
+
+// leaderRotationSteps forces the current leader to step down periodically,
+// so a run exercises leader handover instead of electing once and staying
+// put for the whole scenario.
+const leaderRotationSteps = 120
+
+// Run drives scenario to completion against the real dispatch engine (one
+// per simulated anchor, wired together via an in-process Peer) and returns
+// a report of how well the grid was served.
+func Run(scenario Scenario) (Report, error) {
+	if scenario.Anchors < 1 {
+		return Report{}, fmt.Errorf("sim: scenario needs at least one anchor")
+	}
+
+	loadProfile := LoadProfile(nil)
+	if scenario.LoadProfileCSV != "" {
+		lp, err := LoadLoadProfile(scenario.LoadProfileCSV)
+		if err != nil {
+			return Report{}, err
+		}
+		loadProfile = lp
+	}
+
+	anchors, cleanup, err := buildAnchors(scenario)
+	defer cleanup()
+	if err != nil {
+		return Report{}, err
+	}
+	participants := buildParticipants(scenario)
+
+	fakeClock := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx := context.Background()
+
+	steps := int(scenario.Duration / scenario.StepInterval)
+	socSum := make(map[string]float64, len(anchors)+len(participants))
+	var energyServedKWh, energyUnservedKWh float64
+	leaderChanges := 0
+	leaderID := ""
+	candidateIdx := 0
+
+	for step := 0; step < steps; step++ {
+		fakeClock.Advance(scenario.StepInterval)
+		hour := fakeClock.Now().Hour()
+		irradiance := scenario.SolarIrradianceProfile[hour%len(scenario.SolarIrradianceProfile)]
+
+		views := make([]dispatch.NodeView, 0, len(anchors)+len(participants))
+		for _, a := range anchors {
+			solarKW := a.node.solarCapacityKW * irradiance
+			net := a.node.battery.Step(scenario.StepInterval, solarKW, 0)
+			views = append(views, dispatch.NodeView{ID: a.node.id, BatteryKWh: a.node.battery.SoCKWh, CurrentLoadKW: net})
+			socSum[a.node.id] += a.node.battery.SoCKWh
+		}
+		for _, p := range participants {
+			solarKW := p.solarCapacityKW * irradiance
+			loadKW := loadProfile.At(step)
+			net := p.battery.Step(scenario.StepInterval, solarKW, loadKW)
+			views = append(views, dispatch.NodeView{ID: p.id, BatteryKWh: p.battery.SoCKWh, CurrentLoadKW: net})
+			socSum[p.id] += p.battery.SoCKWh
+		}
+
+		if step > 0 && step%leaderRotationSteps == 0 {
+			for _, a := range anchors {
+				if a.engine.IsLeader() {
+					a.engine.Demote()
+				}
+			}
+			candidateIdx = (candidateIdx + 1) % len(anchors)
+		}
+
+		leader := leadingAnchor(anchors, candidateIdx)
+		if leader == nil {
+			continue
+		}
+		decisions, err := leader.engine.Tick(ctx, views)
+		if err != nil {
+			return Report{}, fmt.Errorf("sim: tick at step %d: %w", step, err)
+		}
+		if leader.node.id != leaderID {
+			if leaderID != "" {
+				leaderChanges++
+			}
+			leaderID = leader.node.id
+		}
+
+		servedBySink := make(map[string]float64)
+		for _, d := range decisions {
+			kWh := d.KW * scenario.StepInterval.Hours()
+			energyServedKWh += kWh
+			servedBySink[d.Sink] += d.KW
+		}
+		for _, v := range views {
+			if v.BatteryKWh-v.CurrentLoadKW >= 0 {
+				continue // not a deficit node
+			}
+			deficitKW := v.CurrentLoadKW - v.BatteryKWh
+			remaining := deficitKW - servedBySink[v.ID]
+			if remaining > 0 {
+				energyUnservedKWh += remaining * scenario.StepInterval.Hours()
+			}
+		}
+	}
+
+	avgSoC := make(map[string]float64, len(socSum))
+	for id, sum := range socSum {
+		if steps > 0 {
+			avgSoC[id] = sum / float64(steps)
+		}
+	}
+
+	unservedPct := 0.0
+	if total := energyServedKWh + energyUnservedKWh; total > 0 {
+		unservedPct = energyUnservedKWh / total * 100
+	}
+
+	return Report{
+		EnergyServedKWh:       energyServedKWh,
+		UnservedEnergyPercent: unservedPct,
+		AverageSoCPerNode:     avgSoC,
+		LeaderChanges:         leaderChanges,
+	}, nil
+}
+
+func buildAnchors(scenario Scenario) ([]*anchorEngine, func(), error) {
+	anchors := make([]*anchorEngine, 0, scenario.Anchors)
+	cleanup := func() {
+		for _, a := range anchors {
+			a.log.Close()
+			os.Remove(a.path)
+		}
+	}
+
+	for i := 0; i < scenario.Anchors; i++ {
+		id := fmt.Sprintf("anchor_%02d", i)
+		f, err := os.CreateTemp("", "streetgrid-sim-"+id+"-*.log")
+		if err != nil {
+			return nil, cleanup, fmt.Errorf("sim: create decision log for %s: %w", id, err)
+		}
+		path := f.Name()
+		f.Close()
+
+		log, err := dispatch.OpenDecisionLog(path)
+		if err != nil {
+			return nil, cleanup, err
+		}
+		node := &simNode{
+			id:              id,
+			isAnchor:        true,
+			solarCapacityKW: scenario.SolarCapacityKW,
+			battery: &Battery{
+				CapacityKWh: scenario.BatteryCapacityKWh,
+				SoCKWh:      scenario.BatteryCapacityKWh * scenario.InitialSoC,
+			},
+		}
+		engine := dispatch.NewEngine(id, dispatch.ProportionalFairStrategy{SurplusThresholdKWh: 0.5}, log, nil)
+		anchors = append(anchors, &anchorEngine{node: node, engine: engine, log: log, path: path})
+	}
+
+	// Wire every anchor's peer list now that all engines exist.
+	for _, a := range anchors {
+		var peers []dispatch.Peer
+		for _, other := range anchors {
+			if other == a {
+				continue
+			}
+			peers = append(peers, &inProcessPeer{id: other.node.id, engine: other.engine})
+		}
+		a.engine.SetPeers(peers)
+	}
+	return anchors, cleanup, nil
+}
+
+func buildParticipants(scenario Scenario) []*simNode {
+	participants := make([]*simNode, 0, scenario.Participants)
+	for i := 0; i < scenario.Participants; i++ {
+		id := fmt.Sprintf("participant_%03d", i)
+		participants = append(participants, &simNode{
+			id:              id,
+			solarCapacityKW: scenario.SolarCapacityKW,
+			battery: &Battery{
+				CapacityKWh: scenario.BatteryCapacityKWh,
+				SoCKWh:      scenario.BatteryCapacityKWh * scenario.InitialSoC,
+			},
+		})
+	}
+	return participants
+}
+
+func leadingAnchor(anchors []*anchorEngine, candidateIdx int) *anchorEngine {
+	for _, a := range anchors {
+		if a.engine.IsLeader() {
+			return a
+		}
+	}
+	// No anchor currently holds leadership; let candidateIdx attempt an
+	// election on its next Tick. Rotating the candidate on every handover
+	// (rather than always retrying anchors[0]) is what actually exercises
+	// leader change instead of the same anchor re-electing itself.
+	if len(anchors) > 0 {
+		return anchors[candidateIdx%len(anchors)]
+	}
+	return nil
+}